@@ -0,0 +1,157 @@
+package merkle
+
+import (
+	"errors"
+	"sort"
+)
+
+// BatchProof is a single Merkle proof covering multiple leaves of a Tree.
+// It omits any sibling hash that can be recomputed from another leaf
+// already being proved, which is a significant size win over independent
+// per-leaf proofs when proving many leaves from the same tree (e.g. many
+// transactions from one block header).
+type BatchProof struct {
+	Indexes   []int
+	LeafCount int
+	Hashes    []string
+}
+
+// GetBatchProof generates a single proof covering the leaves at indexes.
+func (t *Tree[T]) GetBatchProof(indexes []int) (*BatchProof, error) {
+	if len(indexes) == 0 {
+		return nil, errors.New("no indexes given")
+	}
+
+	sorted := uniqueSortedInts(indexes)
+	for _, idx := range sorted {
+		if idx < 0 || idx >= t.LeafCount {
+			return nil, errors.New("index out of range")
+		}
+	}
+
+	known := make(map[int]bool, len(sorted))
+	for _, idx := range sorted {
+		known[t.LeafOffset+idx] = true
+	}
+
+	var hashes []string
+	for len(known) != 1 || !known[0] {
+		level := sortedKeys(known)
+		next := make(map[int]bool)
+
+		for _, idx := range level {
+			siblingIndex := idx - 1
+			if idx%2 == 1 {
+				siblingIndex = idx + 1
+			}
+
+			if !known[siblingIndex] {
+				hash, err := t.store.Get(siblingIndex)
+				if err != nil {
+					return nil, err
+				}
+				hashes = append(hashes, hash)
+			}
+
+			next[(idx-1)/2] = true
+		}
+
+		known = next
+	}
+
+	return &BatchProof{Indexes: sorted, LeafCount: t.LeafCount, Hashes: hashes}, nil
+}
+
+// VerifyBatchProof verifies a BatchProof against root, given the leaf
+// data for every index the proof covers.
+func VerifyBatchProof[T Hashable](leaves map[int]T, proof *BatchProof, rootHash string) bool {
+	if proof == nil || len(proof.Indexes) == 0 {
+		return false
+	}
+
+	paddedLeafCount := nextPowerOfTwo(proof.LeafCount)
+	leafOffset := paddedLeafCount - 1
+
+	current := make(map[int]string, len(proof.Indexes))
+	for _, idx := range proof.Indexes {
+		leaf, ok := leaves[idx]
+		if !ok {
+			return false
+		}
+		current[leafOffset+idx] = hashData(leaf)
+	}
+
+	remaining := proof.Hashes
+
+	for len(current) != 1 || current[0] == "" {
+		level := sortedKeysString(current)
+		next := make(map[int]string)
+
+		for _, idx := range level {
+			isLeft := idx%2 == 1
+			siblingIndex := idx - 1
+			if isLeft {
+				siblingIndex = idx + 1
+			}
+
+			parent := (idx - 1) / 2
+			if _, done := next[parent]; done {
+				continue
+			}
+
+			siblingHash, known := current[siblingIndex]
+			if !known {
+				if len(remaining) == 0 {
+					return false
+				}
+				siblingHash = remaining[0]
+				remaining = remaining[1:]
+			}
+
+			if isLeft {
+				next[parent] = hashNodes(current[idx], siblingHash)
+			} else {
+				next[parent] = hashNodes(siblingHash, current[idx])
+			}
+		}
+
+		current = next
+	}
+
+	return len(remaining) == 0 && current[0] == rootHash
+}
+
+// uniqueSortedInts returns the distinct values of xs in ascending order.
+func uniqueSortedInts(xs []int) []int {
+	sorted := append([]int(nil), xs...)
+	sort.Ints(sorted)
+
+	out := sorted[:0]
+	for i, x := range sorted {
+		if i == 0 || x != sorted[i-1] {
+			out = append(out, x)
+		}
+	}
+	return out
+}
+
+// sortedKeys returns the keys of a set in ascending order.
+func sortedKeys(set map[int]bool) []int {
+	keys := make([]int, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
+
+// sortedKeysString returns the keys of an index-to-hash map in ascending
+// order.
+func sortedKeysString(m map[int]string) []int {
+	keys := make([]int, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Ints(keys)
+	return keys
+}
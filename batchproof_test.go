@@ -0,0 +1,129 @@
+package merkle_test
+
+import (
+	"testing"
+
+	"github.com/kurisu1024/merkle"
+)
+
+func TestGetBatchProofVerifies(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		indexes []int
+	}{
+		{name: "single leaf", indexes: []int{3}},
+		{name: "two adjacent leaves", indexes: []int{0, 1}},
+		{name: "two distant leaves", indexes: []int{0, 7}},
+		{name: "several scattered leaves", indexes: []int{1, 3, 6}},
+		{name: "all leaves", indexes: []int{0, 1, 2, 3, 4, 5, 6, 7}},
+		{name: "unsorted and duplicated indexes", indexes: []int{5, 1, 5, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			proof, err := tree.GetBatchProof(tt.indexes)
+			if err != nil {
+				t.Fatalf("GetBatchProof() error = %v", err)
+			}
+
+			leaves := make(map[int]string)
+			for _, idx := range tt.indexes {
+				leaves[idx] = data[idx]
+			}
+
+			if !merkle.VerifyBatchProof(leaves, proof, tree.GetRoot()) {
+				t.Errorf("VerifyBatchProof() = false, want true for indexes %v", tt.indexes)
+			}
+		})
+	}
+}
+
+func TestGetBatchProofSmallerThanIndependentProofs(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	indexes := []int{0, 1, 2, 3}
+	proof, err := tree.GetBatchProof(indexes)
+	if err != nil {
+		t.Fatalf("GetBatchProof() error = %v", err)
+	}
+
+	var independentTotal int
+	for _, idx := range indexes {
+		p, err := tree.GetProof(idx)
+		if err != nil {
+			t.Fatalf("GetProof(%d) error = %v", idx, err)
+		}
+		independentTotal += len(p)
+	}
+
+	if len(proof.Hashes) >= independentTotal {
+		t.Errorf("batch proof hashes = %d, want fewer than %d from independent proofs", len(proof.Hashes), independentTotal)
+	}
+}
+
+func TestGetBatchProofOutOfRange(t *testing.T) {
+	tree, err := merkle.NewTree([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	if _, err := tree.GetBatchProof([]int{0, 3}); err == nil {
+		t.Error("GetBatchProof() error = nil, want error for out-of-range index")
+	}
+
+	if _, err := tree.GetBatchProof(nil); err == nil {
+		t.Error("GetBatchProof() error = nil, want error for no indexes")
+	}
+}
+
+func TestVerifyBatchProofInvalid(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	indexes := []int{1, 3, 6}
+	proof, err := tree.GetBatchProof(indexes)
+	if err != nil {
+		t.Fatalf("GetBatchProof() error = %v", err)
+	}
+
+	leaves := map[int]string{1: data[1], 3: data[3], 6: data[6]}
+
+	if merkle.VerifyBatchProof(leaves, proof, "wrong_root") {
+		t.Error("VerifyBatchProof() = true, want false for wrong root")
+	}
+
+	wrongLeaves := map[int]string{1: "wrong", 3: data[3], 6: data[6]}
+	if merkle.VerifyBatchProof(wrongLeaves, proof, tree.GetRoot()) {
+		t.Error("VerifyBatchProof() = true, want false for wrong leaf data")
+	}
+
+	missingLeaves := map[int]string{1: data[1], 3: data[3]}
+	if merkle.VerifyBatchProof(missingLeaves, proof, tree.GetRoot()) {
+		t.Error("VerifyBatchProof() = true, want false when a covered leaf is missing")
+	}
+
+	tampered := &merkle.BatchProof{
+		Indexes:   proof.Indexes,
+		LeafCount: proof.LeafCount,
+		Hashes:    append([]string(nil), proof.Hashes...),
+	}
+	if len(tampered.Hashes) > 0 {
+		tampered.Hashes[0] = "tampered_hash"
+		if merkle.VerifyBatchProof(leaves, tampered, tree.GetRoot()) {
+			t.Error("VerifyBatchProof() = true, want false for tampered proof hashes")
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package merkle
+
+import "errors"
+
+// compactNode is an internal node of a CompactTree. Unlike Tree, a
+// CompactTree isn't a flat array addressed by the 2i+1/2i+2 invariant,
+// since lonely leaves are promoted up the tree instead of padded, so the
+// tree is built as an explicit binary structure instead.
+type compactNode struct {
+	hash        string
+	left, right *compactNode
+}
+
+// buildCompactNode recursively builds a minimal-height binary tree over
+// leafHashes without padding: the leaves are split as evenly as possible,
+// with any odd leaf out promoted up a level rather than duplicated.
+func buildCompactNode(leafHashes []string) *compactNode {
+	if len(leafHashes) == 1 {
+		return &compactNode{hash: leafHashes[0]}
+	}
+
+	mid := (len(leafHashes) + 1) / 2
+	left := buildCompactNode(leafHashes[:mid])
+	right := buildCompactNode(leafHashes[mid:])
+
+	return &compactNode{
+		hash:  hashNodes(left.hash, right.hash),
+		left:  left,
+		right: right,
+	}
+}
+
+// compactProofPath descends to the leaf at index within a subtree of
+// count leaves, collecting the sibling hash at each level. Siblings are
+// returned leaf-first (bottom-up), and bit k of path is set when the
+// sibling collected at that position sits on the left.
+func compactProofPath(node *compactNode, count, index int) (siblings []string, path uint64) {
+	if count == 1 {
+		return nil, 0
+	}
+
+	mid := (count + 1) / 2
+	if index < mid {
+		siblings, path = compactProofPath(node.left, mid, index)
+		siblings = append(siblings, node.right.hash)
+		return siblings, path
+	}
+
+	siblings, path = compactProofPath(node.right, count-mid, index-mid)
+	level := uint(len(siblings))
+	siblings = append(siblings, node.left.hash)
+	path |= 1 << level
+	return siblings, path
+}
+
+// CompactTree is a deterministic, minimal-height Merkle tree that does not
+// duplicate leaves for padding. When the leaf count isn't a power of two,
+// the odd leaf out is promoted up the tree instead of paired with a copy
+// of itself, avoiding the second-preimage weakness of padding schemes
+// that hash a leaf against itself.
+type CompactTree[T Hashable] struct {
+	root      *compactNode
+	LeafData  []T
+	LeafCount int
+}
+
+// CompactProof is a membership proof for a CompactTree. Siblings holds
+// the sibling hash at each level from the leaf up to the root. Path
+// encodes, one bit per level (bit k for Siblings[k]), whether that
+// sibling sits on the left.
+type CompactProof struct {
+	Siblings []string
+	Path     uint64
+}
+
+// NewCompactTree creates a new minimal-height Merkle tree from the given
+// data.
+func NewCompactTree[T Hashable](data []T) (*CompactTree[T], error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot create tree with empty data")
+	}
+
+	leafHashes := make([]string, len(data))
+	for i, d := range data {
+		leafHashes[i] = hashData(d)
+	}
+
+	tree := &CompactTree[T]{
+		root:      buildCompactNode(leafHashes),
+		LeafData:  make([]T, len(data)),
+		LeafCount: len(data),
+	}
+	copy(tree.LeafData, data)
+
+	return tree, nil
+}
+
+// GetRoot returns the root hash of the tree.
+func (t *CompactTree[T]) GetRoot() string {
+	if t.root == nil {
+		return ""
+	}
+	return t.root.hash
+}
+
+// GetProof generates a compact Merkle proof for the data at the given
+// index. A single-leaf tree returns an empty proof, since the leaf hash
+// equals the root.
+func (t *CompactTree[T]) GetProof(index int) (*CompactProof, error) {
+	if index < 0 || index >= t.LeafCount {
+		return nil, errors.New("index out of range")
+	}
+
+	siblings, path := compactProofPath(t.root, t.LeafCount, index)
+	return &CompactProof{Siblings: siblings, Path: path}, nil
+}
+
+// VerifyCompactProof verifies a compact Merkle proof for the given data.
+func VerifyCompactProof[T Hashable](data T, proof *CompactProof, rootHash string) bool {
+	hash := hashData(data)
+
+	for level, sibling := range proof.Siblings {
+		if proof.Path&(1<<uint(level)) != 0 {
+			// Sibling is on the left
+			hash = hashNodes(sibling, hash)
+		} else {
+			hash = hashNodes(hash, sibling)
+		}
+	}
+
+	return hash == rootHash
+}
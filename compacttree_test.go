@@ -0,0 +1,146 @@
+package merkle_test
+
+import (
+	"testing"
+
+	"github.com/kurisu1024/merkle"
+)
+
+func TestNewCompactTree(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []string
+		wantErr bool
+	}{
+		{name: "empty data", data: []string{}, wantErr: true},
+		{name: "single element", data: []string{"a"}, wantErr: false},
+		{name: "two elements", data: []string{"a", "b"}, wantErr: false},
+		{name: "three elements", data: []string{"a", "b", "c"}, wantErr: false},
+		{name: "five elements", data: []string{"a", "b", "c", "d", "e"}, wantErr: false},
+		{name: "eight elements", data: []string{"a", "b", "c", "d", "e", "f", "g", "h"}, wantErr: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tree, err := merkle.NewCompactTree(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewCompactTree() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && tree.GetRoot() == "" {
+				t.Error("NewCompactTree() root hash is empty")
+			}
+		})
+	}
+}
+
+func TestCompactTreeSingleLeafRootEqualsLeafHash(t *testing.T) {
+	tree, err := merkle.NewCompactTree([]string{"solo"})
+	if err != nil {
+		t.Fatalf("NewCompactTree() error = %v", err)
+	}
+
+	proof, err := tree.GetProof(0)
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if len(proof.Siblings) != 0 {
+		t.Errorf("GetProof() siblings length = %v, want 0 for single-leaf tree", len(proof.Siblings))
+	}
+
+	if !merkle.VerifyCompactProof("solo", proof, tree.GetRoot()) {
+		t.Error("VerifyCompactProof() = false, want true for single-leaf tree")
+	}
+}
+
+func TestCompactTreeVerifyProofAllLeaves(t *testing.T) {
+	sizes := []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 15}
+
+	for _, size := range sizes {
+		data := make([]string, size)
+		for i := range data {
+			data[i] = string(rune('a' + i))
+		}
+
+		tree, err := merkle.NewCompactTree(data)
+		if err != nil {
+			t.Fatalf("NewCompactTree() error = %v", err)
+		}
+
+		root := tree.GetRoot()
+
+		for i, d := range data {
+			proof, err := tree.GetProof(i)
+			if err != nil {
+				t.Fatalf("GetProof(%d) error = %v", i, err)
+			}
+
+			if !merkle.VerifyCompactProof(d, proof, root) {
+				t.Errorf("size %d: VerifyCompactProof() failed for leaf %d", size, i)
+			}
+		}
+	}
+}
+
+func TestCompactTreeGetProofOutOfRange(t *testing.T) {
+	tree, err := merkle.NewCompactTree([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("NewCompactTree() error = %v", err)
+	}
+
+	if _, err := tree.GetProof(-1); err == nil {
+		t.Error("GetProof(-1) error = nil, want error")
+	}
+	if _, err := tree.GetProof(3); err == nil {
+		t.Error("GetProof(3) error = nil, want error")
+	}
+}
+
+func TestVerifyCompactProofInvalid(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	tree, err := merkle.NewCompactTree(data)
+	if err != nil {
+		t.Fatalf("NewCompactTree() error = %v", err)
+	}
+
+	proof, err := tree.GetProof(0)
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if merkle.VerifyCompactProof("wrong", proof, tree.GetRoot()) {
+		t.Error("VerifyCompactProof() = true, want false for wrong data")
+	}
+
+	if merkle.VerifyCompactProof(data[0], proof, "wrong_hash") {
+		t.Error("VerifyCompactProof() = true, want false for wrong root hash")
+	}
+
+	tampered := &merkle.CompactProof{Siblings: append([]string(nil), proof.Siblings...), Path: proof.Path}
+	if len(tampered.Siblings) > 0 {
+		tampered.Siblings[0] = "tampered_hash"
+		if merkle.VerifyCompactProof(data[0], tampered, tree.GetRoot()) {
+			t.Error("VerifyCompactProof() = true, want false for tampered proof")
+		}
+	}
+}
+
+func TestCompactTreeNoDuplicatedPaddingLeaf(t *testing.T) {
+	// With an odd leaf count, the last leaf must not be duplicated the
+	// way Tree pads; a three-leaf compact tree's root must differ from
+	// what padding-based hashing of the same three leaves would produce.
+	tree, err := merkle.NewCompactTree([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("NewCompactTree() error = %v", err)
+	}
+
+	paddedTree, err := merkle.NewTree([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	if tree.GetRoot() == paddedTree.GetRoot() {
+		t.Error("CompactTree root matches padded Tree root, want different layouts to diverge")
+	}
+}
@@ -0,0 +1,235 @@
+// Package ics23 converts merkle.Tree proofs to and from ICS-23 commitment
+// proofs (github.com/cosmos/ics23/go), the Cosmos SDK / IBC light-client
+// membership proof format, so trees built by this package can interop with
+// ICS-23 verifiers -- the same direction the IAVL project itself moved in,
+// dropping bespoke range proofs in favor of ICS-23 membership proofs.
+//
+// ICS-23's ExistenceProof requires a non-empty leaf key, but merkle.Tree
+// addresses leaves purely by position and has no key of its own. This
+// package uses the leaf's big-endian index (see leafKeyForIndex) as its
+// ICS-23 key, so distinct leaves get distinct keys and a verifier can
+// assert which leaf a proof is for, and hashes values through LeafOp's
+// PrehashValue step. The resulting leaf and root hashes are computed
+// independently of merkle.Tree's own hashing and DO NOT interoperate with
+// Tree.GetRoot() values -- a root produced by Tree.GetRoot() cannot be
+// passed to VerifyCommitmentProof, and a root accepted by
+// VerifyCommitmentProof cannot be compared against Tree.GetRoot(). Always
+// call Root to obtain the ICS-23-side root for a given tree, and track it
+// separately from Tree.GetRoot() if both are needed.
+package ics23
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+
+	ics23proto "github.com/cosmos/ics23/go"
+	"github.com/kurisu1024/merkle"
+)
+
+var (
+	leafPrefix = []byte{0x00}
+	nodePrefix = []byte{0x01}
+)
+
+// leafKeyForIndex returns the ICS-23 key used for the leaf at index: its
+// position as a fixed-width big-endian integer, so every leaf in a tree
+// gets a distinct key a verifier can check against.
+func leafKeyForIndex(index int) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, uint64(index))
+	return key
+}
+
+// leafOp returns the LeafOp used for every leaf this package produces or
+// verifies: hash=SHA256, prehash_value=SHA256, length=NO_PREFIX.
+func leafOp() *ics23proto.LeafOp {
+	return &ics23proto.LeafOp{
+		Hash:         ics23proto.HashOp_SHA256,
+		PrehashKey:   ics23proto.HashOp_NO_HASH,
+		PrehashValue: ics23proto.HashOp_SHA256,
+		Length:       ics23proto.LengthOp_NO_PREFIX,
+		Prefix:       leafPrefix,
+	}
+}
+
+// valueBytes extracts the raw bytes of a merkle.Hashable value.
+func valueBytes[T merkle.Hashable](data T) []byte {
+	switch v := any(data).(type) {
+	case []byte:
+		return v
+	case string:
+		return []byte(v)
+	}
+	return nil
+}
+
+// hashNode combines two child hashes as SHA256(nodePrefix || left ||
+// right), matching the InnerOp this package emits.
+func hashNode(left, right []byte) []byte {
+	data := make([]byte, 0, len(nodePrefix)+len(left)+len(right))
+	data = append(data, nodePrefix...)
+	data = append(data, left...)
+	data = append(data, right...)
+	sum := sha256.Sum256(data)
+	return sum[:]
+}
+
+// nextPowerOfTwo returns the next power of 2 >= n, mirroring merkle's own
+// padding so proof shapes line up with Tree.GetProof.
+func nextPowerOfTwo(n int) int {
+	if n <= 0 {
+		return 1
+	}
+	n--
+	n |= n >> 1
+	n |= n >> 2
+	n |= n >> 4
+	n |= n >> 8
+	n |= n >> 16
+	n++
+	return n
+}
+
+// buildNodes computes this package's ICS-23-compatible leaf hashes and
+// combines them into the same padded, level-order layout merkle.Tree
+// uses, returning the flat node array and the index leaves start at.
+func buildNodes[T merkle.Hashable](tree *merkle.Tree[T]) ([][]byte, int, error) {
+	if !tree.HasLeafData() {
+		return nil, 0, errors.New("tree has no leaf data (e.g. reopened from a snapshot); ics23 requires the original leaves")
+	}
+
+	op := leafOp()
+
+	leafCount := tree.LeafCount
+	leafHashes := make([][]byte, leafCount)
+	for i, d := range tree.LeafData {
+		h, err := op.Apply(leafKeyForIndex(i), valueBytes(d))
+		if err != nil {
+			return nil, 0, err
+		}
+		leafHashes[i] = h
+	}
+
+	padded := nextPowerOfTwo(leafCount)
+	leafOffset := padded - 1
+	nodes := make([][]byte, padded*2-1)
+
+	for i := 0; i < padded; i++ {
+		if i < leafCount {
+			nodes[leafOffset+i] = leafHashes[i]
+		} else {
+			nodes[leafOffset+i] = nodes[leafOffset+leafCount-1]
+		}
+	}
+	for i := leafOffset - 1; i >= 0; i-- {
+		nodes[i] = hashNode(nodes[2*i+1], nodes[2*i+2])
+	}
+
+	return nodes, leafOffset, nil
+}
+
+// Root computes the ICS-23-compatible root hash for tree, hex-encoded to
+// match merkle.Tree.GetRoot's convention.
+func Root[T merkle.Hashable](tree *merkle.Tree[T]) (string, error) {
+	nodes, _, err := buildNodes(tree)
+	if err != nil {
+		return "", err
+	}
+	if len(nodes) == 0 {
+		return "", nil
+	}
+	return hex.EncodeToString(nodes[0]), nil
+}
+
+// ToCommitmentProof builds an ICS-23 CommitmentProof existence proof for
+// the leaf at index. Its path has the same shape as tree.GetProof(index),
+// but its hashes are this package's own (see Root), not the hashes
+// tree.GetProof returns.
+func ToCommitmentProof[T merkle.Hashable](tree *merkle.Tree[T], index int) (*ics23proto.CommitmentProof, error) {
+	if index < 0 || index >= tree.LeafCount {
+		return nil, errors.New("index out of range")
+	}
+
+	nodes, leafOffset, err := buildNodes(tree)
+	if err != nil {
+		return nil, err
+	}
+
+	var path []*ics23proto.InnerOp
+	currentIndex := leafOffset + index
+	for currentIndex > 0 {
+		if currentIndex%2 == 1 {
+			// Current is left child, sibling is on the right
+			sibling := append([]byte(nil), nodes[currentIndex+1]...)
+			path = append(path, &ics23proto.InnerOp{
+				Hash:   ics23proto.HashOp_SHA256,
+				Prefix: append([]byte(nil), nodePrefix...),
+				Suffix: sibling,
+			})
+		} else {
+			// Current is right child, sibling is on the left
+			prefix := append(append([]byte(nil), nodePrefix...), nodes[currentIndex-1]...)
+			path = append(path, &ics23proto.InnerOp{
+				Hash:   ics23proto.HashOp_SHA256,
+				Prefix: prefix,
+				Suffix: nil,
+			})
+		}
+		currentIndex = (currentIndex - 1) / 2
+	}
+
+	existence := &ics23proto.ExistenceProof{
+		Key:   leafKeyForIndex(index),
+		Value: valueBytes(tree.LeafData[index]),
+		Leaf:  leafOp(),
+		Path:  path,
+	}
+
+	return &ics23proto.CommitmentProof{
+		Proof: &ics23proto.CommitmentProof_Exist{Exist: existence},
+	}, nil
+}
+
+// VerifyCommitmentProof verifies that proof is an ICS-23 existence proof
+// for key and value against rootHex, a hex-encoded root produced by Root.
+// Use LeafKeyForIndex to derive the key for a given leaf index. It is not
+// enough for proof to be internally consistent with rootHex: a caller
+// must always check the key/value it expected were the ones proved,
+// which is why both are required here rather than left for the caller to
+// dig out of proof afterwards.
+func VerifyCommitmentProof(proof *ics23proto.CommitmentProof, rootHex string, key, value []byte) bool {
+	if proof == nil {
+		return false
+	}
+
+	existence := proof.GetExist()
+	if existence == nil {
+		return false
+	}
+
+	if !bytes.Equal(existence.Key, key) || !bytes.Equal(existence.Value, value) {
+		return false
+	}
+
+	root, err := hex.DecodeString(rootHex)
+	if err != nil {
+		return false
+	}
+
+	calculated, err := existence.Calculate()
+	if err != nil {
+		return false
+	}
+
+	return bytes.Equal(calculated, root)
+}
+
+// LeafKeyForIndex returns the ICS-23 key VerifyCommitmentProof expects for
+// the leaf at index, matching the key ToCommitmentProof embeds in the
+// proof it produces for that same index.
+func LeafKeyForIndex(index int) []byte {
+	return leafKeyForIndex(index)
+}
@@ -0,0 +1,194 @@
+package ics23_test
+
+import (
+	"errors"
+	"testing"
+
+	ics23proto "github.com/cosmos/ics23/go"
+	"github.com/kurisu1024/merkle"
+	"github.com/kurisu1024/merkle/ics23"
+)
+
+// memKV is a minimal in-memory merkle.KV used to exercise snapshot/reopen
+// behavior without a real embedded store.
+type memKV struct {
+	data map[string][]byte
+}
+
+func newMemKV() *memKV {
+	return &memKV{data: make(map[string][]byte)}
+}
+
+func (m *memKV) Get(key []byte) ([]byte, error) {
+	value, ok := m.data[string(key)]
+	if !ok {
+		return nil, errors.New("key not found")
+	}
+	return value, nil
+}
+
+func (m *memKV) Set(key []byte, value []byte) error {
+	m.data[string(key)] = value
+	return nil
+}
+
+func TestToCommitmentProofVerifies(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	root, err := ics23.Root(tree)
+	if err != nil {
+		t.Fatalf("Root() error = %v", err)
+	}
+	if root == "" {
+		t.Fatal("Root() returned empty string")
+	}
+
+	for i, d := range data {
+		proof, err := ics23.ToCommitmentProof(tree, i)
+		if err != nil {
+			t.Fatalf("ToCommitmentProof(%d) error = %v", i, err)
+		}
+
+		if !ics23.VerifyCommitmentProof(proof, root, ics23.LeafKeyForIndex(i), []byte(d)) {
+			t.Errorf("VerifyCommitmentProof() = false, want true for leaf %d", i)
+		}
+	}
+}
+
+func TestToCommitmentProofSingleLeaf(t *testing.T) {
+	tree, err := merkle.NewTree([]string{"solo"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	root, err := ics23.Root(tree)
+	if err != nil {
+		t.Fatalf("Root() error = %v", err)
+	}
+
+	proof, err := ics23.ToCommitmentProof(tree, 0)
+	if err != nil {
+		t.Fatalf("ToCommitmentProof() error = %v", err)
+	}
+
+	if len(proof.GetExist().GetPath()) != 0 {
+		t.Errorf("path length = %v, want 0 for single-leaf tree", len(proof.GetExist().GetPath()))
+	}
+
+	if !ics23.VerifyCommitmentProof(proof, root, ics23.LeafKeyForIndex(0), []byte("solo")) {
+		t.Error("VerifyCommitmentProof() = false, want true for single-leaf tree")
+	}
+}
+
+func TestToCommitmentProofOutOfRange(t *testing.T) {
+	tree, err := merkle.NewTree([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	if _, err := ics23.ToCommitmentProof(tree, -1); err == nil {
+		t.Error("ToCommitmentProof(-1) error = nil, want error")
+	}
+	if _, err := ics23.ToCommitmentProof(tree, 2); err == nil {
+		t.Error("ToCommitmentProof(2) error = nil, want error")
+	}
+}
+
+func TestVerifyCommitmentProofInvalid(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	root, err := ics23.Root(tree)
+	if err != nil {
+		t.Fatalf("Root() error = %v", err)
+	}
+
+	key := ics23.LeafKeyForIndex(1)
+	value := []byte(data[1])
+
+	proof, err := ics23.ToCommitmentProof(tree, 1)
+	if err != nil {
+		t.Fatalf("ToCommitmentProof() error = %v", err)
+	}
+
+	if ics23.VerifyCommitmentProof(proof, "wrong_root", key, value) {
+		t.Error("VerifyCommitmentProof() = true, want false for wrong root")
+	}
+
+	if ics23.VerifyCommitmentProof(proof, root, ics23.LeafKeyForIndex(2), value) {
+		t.Error("VerifyCommitmentProof() = true, want false for wrong key")
+	}
+
+	if ics23.VerifyCommitmentProof(proof, root, key, []byte("wrong")) {
+		t.Error("VerifyCommitmentProof() = true, want false for wrong expected value")
+	}
+
+	tampered := proof.GetExist()
+	tampered.Value = []byte("wrong")
+	if ics23.VerifyCommitmentProof(proof, root, key, value) {
+		t.Error("VerifyCommitmentProof() = true, want false for tampered value")
+	}
+
+	if ics23.VerifyCommitmentProof(nil, root, key, value) {
+		t.Error("VerifyCommitmentProof() = true, want false for nil proof")
+	}
+
+	if ics23.VerifyCommitmentProof(&ics23proto.CommitmentProof{}, root, key, value) {
+		t.Error("VerifyCommitmentProof() = true, want false for proof with no existence proof")
+	}
+}
+
+func TestToCommitmentProofDistinctLeafKeys(t *testing.T) {
+	data := []string{"a", "a", "a"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	root, err := ics23.Root(tree)
+	if err != nil {
+		t.Fatalf("Root() error = %v", err)
+	}
+
+	proofForIndex1, err := ics23.ToCommitmentProof(tree, 1)
+	if err != nil {
+		t.Fatalf("ToCommitmentProof() error = %v", err)
+	}
+
+	if ics23.VerifyCommitmentProof(proofForIndex1, root, ics23.LeafKeyForIndex(0), []byte("a")) {
+		t.Error("VerifyCommitmentProof() = true, want false when proof is for a different leaf index than expected, even with equal values")
+	}
+}
+
+func TestReopenedSnapshotHasNoLeafData(t *testing.T) {
+	data := []string{"a", "b", "c"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	kv := newMemKV()
+	if err := tree.Snapshot(kv, "root-v1"); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	reopened, err := merkle.OpenSnapshot[string](kv, "root-v1", len(data))
+	if err != nil {
+		t.Fatalf("OpenSnapshot() error = %v", err)
+	}
+
+	if _, err := ics23.ToCommitmentProof(reopened, 1); err == nil {
+		t.Error("ToCommitmentProof() on reopened snapshot error = nil, want error since LeafData is unavailable")
+	}
+
+	if _, err := ics23.Root(reopened); err == nil {
+		t.Error("Root() on reopened snapshot error = nil, want error since LeafData is unavailable")
+	}
+}
@@ -12,14 +12,16 @@ type Hashable interface {
 	~[]byte | ~string
 }
 
-// Tree represents a Merkle tree stored as a flat array
-// Nodes are stored in level-order (breadth-first)
-// For a node at index i: left child = 2*i+1, right child = 2*i+2
+// Tree represents a Merkle tree stored as a flat array addressed in
+// level-order (breadth-first): for a node at index i, left child =
+// 2*i+1, right child = 2*i+2. The array itself lives behind a NodeStore,
+// so a Tree can be backed by plain in-memory storage or by a persistent
+// KV store for trees too large to hold in RAM.
 type Tree[T Hashable] struct {
-	Nodes      []string // Hash values stored in level-order
-	LeafData   []T      // Original data for leaves
-	LeafOffset int      // Index where leaves start in Nodes array
-	LeafCount  int      // Number of leaves
+	store      NodeStore
+	LeafData   []T // Original data for leaves
+	LeafOffset int // Index where leaves start in the node store
+	LeafCount  int // Number of leaves
 }
 
 // hashData converts data to a hash string
@@ -57,36 +59,29 @@ func nextPowerOfTwo(n int) int {
 	return n
 }
 
-// NewTree creates a new Merkle tree from the given data
-func NewTree[T Hashable](data []T) (*Tree[T], error) {
-	if len(data) == 0 {
-		return nil, errors.New("cannot create tree with empty data")
-	}
-
-	leafCount := len(data)
+// buildTree assembles a flat, level-order complete binary tree from
+// already-computed leaf hashes, padding with a duplicate of the last leaf
+// when the leaf count isn't a power of two. It returns the node array and
+// the index where leaves begin, and is shared by NewTree and SimpleMap so
+// both commit to their leaves using the same layout.
+func buildTree(leafHashes []string) (nodes []string, leafOffset int) {
+	leafCount := len(leafHashes)
 	// Pad to next power of 2 for a complete binary tree
 	paddedLeafCount := nextPowerOfTwo(leafCount)
 
 	// Total nodes = paddedLeafCount * 2 - 1 (complete binary tree)
 	totalNodes := paddedLeafCount*2 - 1
-	leafOffset := paddedLeafCount - 1
+	leafOffset = paddedLeafCount - 1
 
-	tree := &Tree[T]{
-		Nodes:      make([]string, totalNodes),
-		LeafData:   make([]T, leafCount),
-		LeafOffset: leafOffset,
-		LeafCount:  leafCount,
-	}
-
-	copy(tree.LeafData, data)
+	nodes = make([]string, totalNodes)
 
-	// Hash all leaves (including padding)
+	// Place all leaves (including padding)
 	for i := 0; i < paddedLeafCount; i++ {
 		if i < leafCount {
-			tree.Nodes[leafOffset+i] = hashData(data[i])
+			nodes[leafOffset+i] = leafHashes[i]
 		} else {
 			// Duplicate last leaf for padding
-			tree.Nodes[leafOffset+i] = tree.Nodes[leafOffset+leafCount-1]
+			nodes[leafOffset+i] = nodes[leafOffset+leafCount-1]
 		}
 	}
 
@@ -94,28 +89,70 @@ func NewTree[T Hashable](data []T) (*Tree[T], error) {
 	for i := leafOffset - 1; i >= 0; i-- {
 		left := 2*i + 1
 		right := 2*i + 2
-		tree.Nodes[i] = hashNodes(tree.Nodes[left], tree.Nodes[right])
+		nodes[i] = hashNodes(nodes[left], nodes[right])
+	}
+
+	return nodes, leafOffset
+}
+
+// NewTree creates a new Merkle tree from the given data
+func NewTree[T Hashable](data []T) (*Tree[T], error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot create tree with empty data")
+	}
+
+	leafCount := len(data)
+	leafHashes := make([]string, leafCount)
+	for i, d := range data {
+		leafHashes[i] = hashData(d)
+	}
+
+	nodes, leafOffset := buildTree(leafHashes)
+
+	tree := &Tree[T]{
+		store:      NewInMemoryStore(nodes),
+		LeafData:   make([]T, leafCount),
+		LeafOffset: leafOffset,
+		LeafCount:  leafCount,
 	}
 
+	copy(tree.LeafData, data)
+
 	return tree, nil
 }
 
 // GetRoot returns the root hash of the tree
 func (t *Tree[T]) GetRoot() string {
-	if len(t.Nodes) == 0 {
+	if t.store == nil || t.store.Len() == 0 {
 		return ""
 	}
-	return t.Nodes[0]
+	root, err := t.store.Get(0)
+	if err != nil {
+		return ""
+	}
+	return root
 }
 
-// GetProof generates a Merkle proof for the data at the given index
+// GetProof generates a Merkle proof for the data at the given index. It
+// only reads the ~log2(LeafCount) nodes along the path to the root, so a
+// Tree backed by a persistent NodeStore doesn't need its other nodes in
+// memory.
 func (t *Tree[T]) GetProof(index int) ([]string, error) {
 	if index < 0 || index >= t.LeafCount {
 		return nil, errors.New("index out of range")
 	}
 
+	return proofFromNodes(t.store, t.LeafOffset, index)
+}
+
+// proofFromNodes walks up from the leaf at leafOffset+index to the root,
+// collecting the sibling hash at each level from store, the flat,
+// level-order layout produced by buildTree. Tree.GetProof uses it;
+// SimpleMap.GetProof walks the same layout itself, since it tags each
+// sibling with its side.
+func proofFromNodes(store NodeStore, leafOffset, index int) ([]string, error) {
 	var proof []string
-	currentIndex := t.LeafOffset + index
+	currentIndex := leafOffset + index
 
 	for currentIndex > 0 {
 		// Find sibling
@@ -128,7 +165,11 @@ func (t *Tree[T]) GetProof(index int) ([]string, error) {
 			siblingIndex = currentIndex - 1
 		}
 
-		proof = append(proof, t.Nodes[siblingIndex])
+		hash, err := store.Get(siblingIndex)
+		if err != nil {
+			return nil, err
+		}
+		proof = append(proof, hash)
 
 		// Move to parent
 		currentIndex = (currentIndex - 1) / 2
@@ -155,7 +196,7 @@ func VerifyProof[T Hashable](data T, proof []string, rootHash string, index int)
 
 // Print prints the tree structure
 func (t *Tree[T]) Print() {
-	if len(t.Nodes) == 0 {
+	if t.store == nil || t.store.Len() == 0 {
 		fmt.Println("Empty tree")
 		return
 	}
@@ -163,7 +204,12 @@ func (t *Tree[T]) Print() {
 }
 
 func (t *Tree[T]) printNode(index int, prefix string, isTail bool) {
-	if index >= len(t.Nodes) {
+	if index >= t.store.Len() {
+		return
+	}
+
+	hash, err := t.store.Get(index)
+	if err != nil {
 		return
 	}
 
@@ -172,21 +218,21 @@ func (t *Tree[T]) printNode(index int, prefix string, isTail bool) {
 		connector = "├── "
 	}
 
-	fmt.Printf("%s%s%s\n", prefix, connector, t.Nodes[index][:8]+"...")
+	fmt.Printf("%s%s%s\n", prefix, connector, hash[:8]+"...")
 
 	leftChild := 2*index + 1
 	rightChild := 2*index + 2
 
-	if leftChild < len(t.Nodes) || rightChild < len(t.Nodes) {
+	if leftChild < t.store.Len() || rightChild < t.store.Len() {
 		extension := "    "
 		if !isTail {
 			extension = "│   "
 		}
 
-		if rightChild < len(t.Nodes) {
+		if rightChild < t.store.Len() {
 			t.printNode(rightChild, prefix+extension, false)
 		}
-		if leftChild < len(t.Nodes) {
+		if leftChild < t.store.Len() {
 			t.printNode(leftChild, prefix+extension, true)
 		}
 	}
@@ -0,0 +1,151 @@
+package merkle
+
+import (
+	"errors"
+	"fmt"
+)
+
+// NodeStore abstracts how a Tree's flat, level-order node array is
+// stored. InMemoryStore, the default, simply holds every hash in a
+// slice; PersistentStore backs the same interface with any KV store so
+// trees with millions of leaves don't require holding all 2N-1 hex
+// hashes in RAM, and GetProof only reads the ~log2N nodes it needs along
+// a proof path.
+type NodeStore interface {
+	Get(index int) (string, error)
+	Put(index int, hash string) error
+	Len() int
+}
+
+// InMemoryStore is the default NodeStore: a flat in-memory slice of
+// hashes, identical to how Tree stored nodes before NodeStore existed.
+type InMemoryStore struct {
+	nodes []string
+}
+
+// NewInMemoryStore wraps an existing slice of hashes as a NodeStore.
+func NewInMemoryStore(nodes []string) *InMemoryStore {
+	return &InMemoryStore{nodes: nodes}
+}
+
+func (s *InMemoryStore) Get(index int) (string, error) {
+	if index < 0 || index >= len(s.nodes) {
+		return "", errors.New("node index out of range")
+	}
+	return s.nodes[index], nil
+}
+
+func (s *InMemoryStore) Put(index int, hash string) error {
+	if index < 0 || index >= len(s.nodes) {
+		return errors.New("node index out of range")
+	}
+	s.nodes[index] = hash
+	return nil
+}
+
+func (s *InMemoryStore) Len() int {
+	return len(s.nodes)
+}
+
+// KV is a minimal persistent key-value interface. PersistentStore backs
+// a Tree with any store that satisfies it -- an adapter over pebble,
+// bbolt, badger, or similar embedded KV stores.
+type KV interface {
+	Get(key []byte) ([]byte, error)
+	Set(key []byte, value []byte) error
+}
+
+// PersistentStore is a NodeStore backed by a KV store, scoped under a
+// root-specific key prefix so multiple historical roots can coexist in
+// the same underlying store, analogous to the immutable-snapshot pattern
+// IAVL and Gossamer's trie use for state at different block heights.
+// Nodes are read and written through to kv on every call; it does not
+// cache, so repeated reads of the same node hit kv each time.
+type PersistentStore struct {
+	kv     KV
+	prefix string
+	size   int
+}
+
+// NewPersistentStore creates a PersistentStore of size nodes, scoped
+// under prefix within kv.
+func NewPersistentStore(kv KV, prefix string, size int) *PersistentStore {
+	return &PersistentStore{kv: kv, prefix: prefix, size: size}
+}
+
+func (s *PersistentStore) key(index int) []byte {
+	return []byte(fmt.Sprintf("%s/%d", s.prefix, index))
+}
+
+func (s *PersistentStore) Get(index int) (string, error) {
+	if index < 0 || index >= s.size {
+		return "", errors.New("node index out of range")
+	}
+	value, err := s.kv.Get(s.key(index))
+	if err != nil {
+		return "", err
+	}
+	return string(value), nil
+}
+
+func (s *PersistentStore) Put(index int, hash string) error {
+	if index < 0 || index >= s.size {
+		return errors.New("node index out of range")
+	}
+	return s.kv.Set(s.key(index), []byte(hash))
+}
+
+func (s *PersistentStore) Len() int {
+	return s.size
+}
+
+// Snapshot writes every node of t into kv under a prefix scoped to
+// rootID, so the tree at this root can be reopened later (with
+// OpenSnapshot) alongside snapshots of other roots in the same kv.
+func (t *Tree[T]) Snapshot(kv KV, rootID string) error {
+	store := NewPersistentStore(kv, rootID, t.store.Len())
+	for i := 0; i < t.store.Len(); i++ {
+		hash, err := t.store.Get(i)
+		if err != nil {
+			return err
+		}
+		if err := store.Put(i, hash); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// HasLeafData reports whether t.LeafData holds the tree's actual leaves.
+// It is false for trees returned by OpenSnapshot, which only persist node
+// hashes; callers that index into LeafData (directly, or indirectly via
+// packages like ics23) must check this first instead of trusting
+// LeafCount alone, since LeafCount stays at the real leaf count even
+// when LeafData is empty.
+func (t *Tree[T]) HasLeafData() bool {
+	return len(t.LeafData) == t.LeafCount
+}
+
+// OpenSnapshot reopens a tree of leafCount leaves previously written by
+// Snapshot under rootID, lazily reading nodes from kv as GetRoot and
+// GetProof need them rather than loading the whole tree into memory. The
+// returned tree has no LeafData, since a snapshot only persists hashes --
+// LeafCount still reflects the real leaf count, so callers that need the
+// original leaves (directly, or via LeafData-consumers like ics23) must
+// check HasLeafData first rather than assuming LeafCount implies LeafData
+// is populated.
+func OpenSnapshot[T Hashable](kv KV, rootID string, leafCount int) (*Tree[T], error) {
+	if leafCount <= 0 {
+		return nil, errors.New("cannot open snapshot with non-positive leaf count")
+	}
+
+	paddedLeafCount := nextPowerOfTwo(leafCount)
+	totalNodes := paddedLeafCount*2 - 1
+	leafOffset := paddedLeafCount - 1
+
+	return &Tree[T]{
+		store:      NewPersistentStore(kv, rootID, totalNodes),
+		LeafOffset: leafOffset,
+		LeafCount:  leafCount,
+	}, nil
+}
@@ -0,0 +1,124 @@
+package merkle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/kurisu1024/merkle"
+)
+
+// memKV is a trivial in-memory merkle.KV used to exercise
+// PersistentStore and Snapshot/OpenSnapshot without a real embedded
+// store.
+type memKV struct {
+	data map[string][]byte
+}
+
+func newMemKV() *memKV {
+	return &memKV{data: make(map[string][]byte)}
+}
+
+func (m *memKV) Get(key []byte) ([]byte, error) {
+	value, ok := m.data[string(key)]
+	if !ok {
+		return nil, errors.New("key not found")
+	}
+	return value, nil
+}
+
+func (m *memKV) Set(key []byte, value []byte) error {
+	m.data[string(key)] = value
+	return nil
+}
+
+func TestSnapshotAndOpenSnapshot(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e"}
+	tree, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	kv := newMemKV()
+	if err := tree.Snapshot(kv, "root-v1"); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	reopened, err := merkle.OpenSnapshot[string](kv, "root-v1", len(data))
+	if err != nil {
+		t.Fatalf("OpenSnapshot() error = %v", err)
+	}
+
+	if reopened.GetRoot() != tree.GetRoot() {
+		t.Errorf("OpenSnapshot() root = %v, want %v", reopened.GetRoot(), tree.GetRoot())
+	}
+
+	for i, d := range data {
+		proof, err := reopened.GetProof(i)
+		if err != nil {
+			t.Fatalf("GetProof(%d) error = %v", i, err)
+		}
+		if !merkle.VerifyProof(d, proof, reopened.GetRoot(), i) {
+			t.Errorf("VerifyProof() failed for leaf %d on reopened snapshot", i)
+		}
+	}
+
+	if tree.HasLeafData() == false {
+		t.Errorf("HasLeafData() = false on a freshly built tree, want true")
+	}
+	if reopened.HasLeafData() {
+		t.Errorf("HasLeafData() = true on a reopened snapshot, want false")
+	}
+}
+
+func TestSnapshotMultipleRootsCoexist(t *testing.T) {
+	kv := newMemKV()
+
+	treeV1, err := merkle.NewTree([]string{"a", "b", "c"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+	if err := treeV1.Snapshot(kv, "v1"); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	treeV2, err := merkle.NewTree([]string{"a", "b", "x"})
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+	if err := treeV2.Snapshot(kv, "v2"); err != nil {
+		t.Fatalf("Snapshot() error = %v", err)
+	}
+
+	reopenedV1, err := merkle.OpenSnapshot[string](kv, "v1", 3)
+	if err != nil {
+		t.Fatalf("OpenSnapshot(v1) error = %v", err)
+	}
+	reopenedV2, err := merkle.OpenSnapshot[string](kv, "v2", 3)
+	if err != nil {
+		t.Fatalf("OpenSnapshot(v2) error = %v", err)
+	}
+
+	if reopenedV1.GetRoot() != treeV1.GetRoot() {
+		t.Error("reopened v1 root does not match original")
+	}
+	if reopenedV2.GetRoot() != treeV2.GetRoot() {
+		t.Error("reopened v2 root does not match original")
+	}
+	if reopenedV1.GetRoot() == reopenedV2.GetRoot() {
+		t.Error("snapshots for different roots collided")
+	}
+}
+
+func TestInMemoryStoreOutOfRange(t *testing.T) {
+	store := merkle.NewInMemoryStore(make([]string, 3))
+
+	if _, err := store.Get(-1); err == nil {
+		t.Error("Get(-1) error = nil, want error")
+	}
+	if _, err := store.Get(3); err == nil {
+		t.Error("Get(3) error = nil, want error")
+	}
+	if err := store.Put(3, "hash"); err == nil {
+		t.Error("Put(3, ...) error = nil, want error")
+	}
+}
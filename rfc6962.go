@@ -0,0 +1,159 @@
+package merkle
+
+import (
+	"encoding/hex"
+	"errors"
+	"hash"
+)
+
+// Options configures the hash function and domain-separation prefixes
+// used by NewTreeWithOptions. LeafPrefix is prepended before hashing leaf
+// data and NodePrefix before hashing a pair of child hashes, so a leaf
+// hash can never be replayed as an internal node hash (and vice versa) --
+// the classic second-preimage weakness of undifferentiated Merkle trees.
+// Hash selects the underlying hash function, e.g. sha256.New,
+// ripemd160.New, or sha3.NewLegacyKeccak256 for interop with
+// Ethereum/Cosmos ecosystems.
+type Options struct {
+	LeafPrefix []byte
+	NodePrefix []byte
+	Hash       func() hash.Hash
+}
+
+// hashDataWithOptions hashes leaf data as Hash(LeafPrefix || data).
+func hashDataWithOptions[T Hashable](data T, opts Options) string {
+	var b []byte
+	switch v := any(data).(type) {
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	}
+
+	h := opts.Hash()
+	h.Write(opts.LeafPrefix)
+	h.Write(b)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// hashNodesWithOptions combines two child hashes as
+// Hash(NodePrefix || left || right). left and right are expected to be
+// hex-encoded hashes, but either may come from caller-supplied proof data
+// (see VerifyProofWithOptions), so a decode failure is reported as an
+// error rather than panicking.
+func hashNodesWithOptions(left, right string, opts Options) (string, error) {
+	leftBytes, err := hex.DecodeString(left)
+	if err != nil {
+		return "", errors.New("merkle: malformed hash: " + err.Error())
+	}
+	rightBytes, err := hex.DecodeString(right)
+	if err != nil {
+		return "", errors.New("merkle: malformed hash: " + err.Error())
+	}
+
+	h := opts.Hash()
+	h.Write(opts.NodePrefix)
+	h.Write(leftBytes)
+	h.Write(rightBytes)
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// buildTreeWithOptions is the Options-aware counterpart to buildTree.
+func buildTreeWithOptions(leafHashes []string, opts Options) (nodes []string, leafOffset int) {
+	leafCount := len(leafHashes)
+	paddedLeafCount := nextPowerOfTwo(leafCount)
+	totalNodes := paddedLeafCount*2 - 1
+	leafOffset = paddedLeafCount - 1
+
+	nodes = make([]string, totalNodes)
+	for i := 0; i < paddedLeafCount; i++ {
+		if i < leafCount {
+			nodes[leafOffset+i] = leafHashes[i]
+		} else {
+			nodes[leafOffset+i] = nodes[leafOffset+leafCount-1]
+		}
+	}
+
+	for i := leafOffset - 1; i >= 0; i-- {
+		left := 2*i + 1
+		right := 2*i + 2
+		// nodes[left]/nodes[right] are always hex produced by this
+		// package itself, so the only way to hit an error here is a bug
+		// in hashDataWithOptions/buildTreeWithOptions.
+		hash, err := hashNodesWithOptions(nodes[left], nodes[right], opts)
+		if err != nil {
+			panic(err)
+		}
+		nodes[i] = hash
+	}
+
+	return nodes, leafOffset
+}
+
+// NewTreeWithOptions creates a new Merkle tree from the given data using a
+// configurable hash function and domain-separation prefixes, e.g.
+//
+//	NewTreeWithOptions(data, Options{
+//		LeafPrefix: []byte{0x00},
+//		NodePrefix: []byte{0x01},
+//		Hash:       sha256.New,
+//	})
+//
+// uses the RFC 6962 Certificate Transparency leaf and node hashing. The
+// tree is still padded by duplicating the last leaf, as NewTree does, so
+// for a leaf count that is not a power of two its root is not the RFC
+// 6962 root of the same leaves.
+func NewTreeWithOptions[T Hashable](data []T, opts Options) (*Tree[T], error) {
+	if len(data) == 0 {
+		return nil, errors.New("cannot create tree with empty data")
+	}
+	if opts.Hash == nil {
+		return nil, errors.New("options: Hash function must be set")
+	}
+
+	leafCount := len(data)
+	leafHashes := make([]string, leafCount)
+	for i, d := range data {
+		leafHashes[i] = hashDataWithOptions(d, opts)
+	}
+
+	nodes, leafOffset := buildTreeWithOptions(leafHashes, opts)
+
+	tree := &Tree[T]{
+		store:      NewInMemoryStore(nodes),
+		LeafData:   make([]T, leafCount),
+		LeafOffset: leafOffset,
+		LeafCount:  leafCount,
+	}
+	copy(tree.LeafData, data)
+
+	return tree, nil
+}
+
+// VerifyProofWithOptions verifies a Merkle proof produced against a tree
+// built with NewTreeWithOptions. opts must match the Options the tree was
+// built with. It returns false, rather than panicking, on a malformed
+// proof or misconfigured opts, since proof and index are expected to come
+// from an untrusted peer.
+func VerifyProofWithOptions[T Hashable](data T, proof []string, rootHash string, index int, opts Options) bool {
+	if opts.Hash == nil {
+		return false
+	}
+
+	current := hashDataWithOptions(data, opts)
+
+	for _, siblingHash := range proof {
+		var err error
+		if index%2 == 0 {
+			current, err = hashNodesWithOptions(current, siblingHash, opts)
+		} else {
+			current, err = hashNodesWithOptions(siblingHash, current, opts)
+		}
+		if err != nil {
+			return false
+		}
+		index = index / 2
+	}
+
+	return current == rootHash
+}
@@ -0,0 +1,137 @@
+package merkle_test
+
+import (
+	"crypto/sha256"
+	"testing"
+
+	"github.com/kurisu1024/merkle"
+)
+
+func rfc6962Options() merkle.Options {
+	return merkle.Options{
+		LeafPrefix: []byte{0x00},
+		NodePrefix: []byte{0x01},
+		Hash:       sha256.New,
+	}
+}
+
+func TestNewTreeWithOptions(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e"}
+
+	tree, err := merkle.NewTreeWithOptions(data, rfc6962Options())
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	if tree.GetRoot() == "" {
+		t.Error("NewTreeWithOptions() root hash is empty")
+	}
+}
+
+func TestNewTreeWithOptionsRequiresHash(t *testing.T) {
+	_, err := merkle.NewTreeWithOptions([]string{"a"}, merkle.Options{})
+	if err == nil {
+		t.Error("NewTreeWithOptions() error = nil, want error when Hash is unset")
+	}
+}
+
+func TestNewTreeWithOptionsDiffersFromNewTree(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+
+	plain, err := merkle.NewTree(data)
+	if err != nil {
+		t.Fatalf("NewTree() error = %v", err)
+	}
+
+	prefixed, err := merkle.NewTreeWithOptions(data, rfc6962Options())
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	if plain.GetRoot() == prefixed.GetRoot() {
+		t.Error("domain-separated root matches unprefixed root, want them to diverge")
+	}
+}
+
+func TestVerifyProofWithOptions(t *testing.T) {
+	data := []string{"a", "b", "c", "d", "e", "f", "g"}
+	opts := rfc6962Options()
+
+	tree, err := merkle.NewTreeWithOptions(data, opts)
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	root := tree.GetRoot()
+
+	for i, d := range data {
+		proof, err := tree.GetProof(i)
+		if err != nil {
+			t.Fatalf("GetProof(%d) error = %v", i, err)
+		}
+
+		if !merkle.VerifyProofWithOptions(d, proof, root, i, opts) {
+			t.Errorf("VerifyProofWithOptions() = false, want true for leaf %d", i)
+		}
+	}
+}
+
+func TestVerifyProofWithOptionsInvalid(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	opts := rfc6962Options()
+
+	tree, err := merkle.NewTreeWithOptions(data, opts)
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	proof, err := tree.GetProof(0)
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if merkle.VerifyProofWithOptions("wrong", proof, tree.GetRoot(), 0, opts) {
+		t.Error("VerifyProofWithOptions() = true, want false for wrong data")
+	}
+
+	if merkle.VerifyProofWithOptions(data[0], proof, tree.GetRoot(), 0, merkle.Options{
+		LeafPrefix: []byte{0x00},
+		NodePrefix: []byte{0x02},
+		Hash:       sha256.New,
+	}) {
+		t.Error("VerifyProofWithOptions() = true, want false for mismatched NodePrefix")
+	}
+}
+
+func TestVerifyProofWithOptionsMalformedProof(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	opts := rfc6962Options()
+
+	tree, err := merkle.NewTreeWithOptions(data, opts)
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	if merkle.VerifyProofWithOptions(data[0], []string{"not-hex!!"}, tree.GetRoot(), 0, opts) {
+		t.Error("VerifyProofWithOptions() = true, want false for non-hex sibling hash")
+	}
+}
+
+func TestVerifyProofWithOptionsRequiresHash(t *testing.T) {
+	data := []string{"a", "b", "c", "d"}
+	opts := rfc6962Options()
+
+	tree, err := merkle.NewTreeWithOptions(data, opts)
+	if err != nil {
+		t.Fatalf("NewTreeWithOptions() error = %v", err)
+	}
+
+	proof, err := tree.GetProof(0)
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if merkle.VerifyProofWithOptions(data[0], proof, tree.GetRoot(), 0, merkle.Options{}) {
+		t.Error("VerifyProofWithOptions() = true, want false when opts.Hash is unset")
+	}
+}
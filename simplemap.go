@@ -0,0 +1,115 @@
+package merkle
+
+import (
+	"errors"
+	"sort"
+)
+
+// SimpleMap is a key-value Merkle map that commits to a set of key/value
+// pairs with a deterministic root computed over the pairs in sorted-key
+// order, mirroring the sorted-KVPair Merkle map construction used by
+// Tendermint's SimpleMap. Each entry is hashed as H(H(key) || H(value)),
+// and the resulting leaf hashes are fed into the same tree layout used by
+// Tree, so users can commit to arbitrary maps without tracking leaf
+// indexes themselves.
+type SimpleMap[K ~string, V Hashable] struct {
+	entries map[K]V
+}
+
+// NewSimpleMap creates an empty key-value Merkle map.
+func NewSimpleMap[K ~string, V Hashable]() *SimpleMap[K, V] {
+	return &SimpleMap[K, V]{entries: make(map[K]V)}
+}
+
+// Set inserts or updates the value stored under key.
+func (m *SimpleMap[K, V]) Set(key K, value V) {
+	m.entries[key] = value
+}
+
+// entryHash hashes a key/value pair as H(H(key) || H(value)).
+func entryHash[K ~string, V Hashable](key K, value V) string {
+	return hashNodes(hashData(string(key)), hashData(value))
+}
+
+// sortedLeafHashes returns the map's keys and their entry hashes, both
+// ordered by ascending key, so the Merkle tree is built deterministically
+// regardless of map iteration order.
+func (m *SimpleMap[K, V]) sortedLeafHashes() ([]K, []string) {
+	keys := make([]K, 0, len(m.entries))
+	for k := range m.entries {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	hashes := make([]string, len(keys))
+	for i, k := range keys {
+		hashes[i] = entryHash(k, m.entries[k])
+	}
+
+	return keys, hashes
+}
+
+// Root computes the deterministic Merkle root over the map's entries
+// sorted by key. It returns an empty string for an empty map.
+func (m *SimpleMap[K, V]) Root() string {
+	_, hashes := m.sortedLeafHashes()
+	if len(hashes) == 0 {
+		return ""
+	}
+
+	nodes, _ := buildTree(hashes)
+	return nodes[0]
+}
+
+// GetProof generates a membership proof for key. Each proof entry is the
+// sibling hash at one level, tagged with an "L" or "R" prefix marking
+// which side it sits on, so VerifyMapProof can rebuild the path without
+// needing to know the key's position among the map's sorted entries.
+func (m *SimpleMap[K, V]) GetProof(key K) ([]string, error) {
+	keys, hashes := m.sortedLeafHashes()
+	index := sort.Search(len(keys), func(i int) bool { return keys[i] >= key })
+	if index >= len(keys) || keys[index] != key {
+		return nil, errors.New("key not found")
+	}
+
+	nodes, leafOffset := buildTree(hashes)
+
+	var proof []string
+	currentIndex := leafOffset + index
+	for currentIndex > 0 {
+		if currentIndex%2 == 1 {
+			// Current is left child, sibling is on the right
+			proof = append(proof, "R"+nodes[currentIndex+1])
+		} else {
+			// Current is right child, sibling is on the left
+			proof = append(proof, "L"+nodes[currentIndex-1])
+		}
+		currentIndex = (currentIndex - 1) / 2
+	}
+
+	return proof, nil
+}
+
+// VerifyMapProof verifies a membership proof produced by
+// SimpleMap.GetProof for the given key/value pair against root.
+func VerifyMapProof[K ~string, V Hashable](key K, value V, proof []string, root string) bool {
+	hash := entryHash(key, value)
+
+	for _, tagged := range proof {
+		if len(tagged) < 2 {
+			return false
+		}
+
+		side, siblingHash := tagged[0], tagged[1:]
+		switch side {
+		case 'R':
+			hash = hashNodes(hash, siblingHash)
+		case 'L':
+			hash = hashNodes(siblingHash, hash)
+		default:
+			return false
+		}
+	}
+
+	return hash == root
+}
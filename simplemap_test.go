@@ -0,0 +1,129 @@
+package merkle_test
+
+import (
+	"testing"
+
+	"github.com/kurisu1024/merkle"
+)
+
+func TestSimpleMapRoot(t *testing.T) {
+	m1 := merkle.NewSimpleMap[string, string]()
+	m1.Set("b", "2")
+	m1.Set("a", "1")
+	m1.Set("c", "3")
+
+	m2 := merkle.NewSimpleMap[string, string]()
+	m2.Set("c", "3")
+	m2.Set("a", "1")
+	m2.Set("b", "2")
+
+	if m1.Root() != m2.Root() {
+		t.Error("Root() depends on insertion order, want order-independent")
+	}
+
+	if m1.Root() == "" {
+		t.Error("Root() returned empty string for non-empty map")
+	}
+}
+
+func TestSimpleMapRootEmpty(t *testing.T) {
+	m := merkle.NewSimpleMap[string, string]()
+	if root := m.Root(); root != "" {
+		t.Errorf("Root() = %q, want empty string for empty map", root)
+	}
+}
+
+func TestSimpleMapRootDifferentData(t *testing.T) {
+	m1 := merkle.NewSimpleMap[string, string]()
+	m1.Set("a", "1")
+
+	m2 := merkle.NewSimpleMap[string, string]()
+	m2.Set("a", "2")
+
+	if m1.Root() == m2.Root() {
+		t.Error("Different values produced same root hash")
+	}
+}
+
+func TestSimpleMapGetProof(t *testing.T) {
+	keys := []string{"apple", "banana", "cherry", "date", "elderberry"}
+
+	m := merkle.NewSimpleMap[string, string]()
+	for i, k := range keys {
+		m.Set(k, string(rune('0'+i)))
+	}
+
+	root := m.Root()
+
+	for i, k := range keys {
+		proof, err := m.GetProof(k)
+		if err != nil {
+			t.Fatalf("GetProof(%q) error = %v", k, err)
+		}
+
+		if !merkle.VerifyMapProof(k, string(rune('0'+i)), proof, root) {
+			t.Errorf("VerifyMapProof() = false, want true for key %q", k)
+		}
+	}
+}
+
+func TestSimpleMapGetProofSingleEntry(t *testing.T) {
+	m := merkle.NewSimpleMap[string, string]()
+	m.Set("only", "value")
+
+	proof, err := m.GetProof("only")
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if len(proof) != 0 {
+		t.Errorf("GetProof() proof length = %v, want 0 for single-entry map", len(proof))
+	}
+
+	if !merkle.VerifyMapProof[string]("only", "value", proof, m.Root()) {
+		t.Error("VerifyMapProof() = false, want true for single-entry map")
+	}
+}
+
+func TestSimpleMapGetProofMissingKey(t *testing.T) {
+	m := merkle.NewSimpleMap[string, string]()
+	m.Set("a", "1")
+	m.Set("c", "3")
+
+	if _, err := m.GetProof("b"); err == nil {
+		t.Error("GetProof() error = nil, want error for missing key")
+	}
+}
+
+func TestVerifyMapProofInvalid(t *testing.T) {
+	m := merkle.NewSimpleMap[string, string]()
+	m.Set("a", "1")
+	m.Set("b", "2")
+	m.Set("c", "3")
+
+	root := m.Root()
+	proof, err := m.GetProof("a")
+	if err != nil {
+		t.Fatalf("GetProof() error = %v", err)
+	}
+
+	if merkle.VerifyMapProof("a", "wrong", proof, root) {
+		t.Error("VerifyMapProof() = true, want false for wrong value")
+	}
+
+	if merkle.VerifyMapProof("a", "1", proof, "wrong_root") {
+		t.Error("VerifyMapProof() = true, want false for wrong root")
+	}
+
+	if merkle.VerifyMapProof("b", "2", proof, root) {
+		t.Error("VerifyMapProof() = true, want false for mismatched key")
+	}
+
+	tampered := append([]string(nil), proof...)
+	if len(tampered) > 0 {
+		tampered[0] = "Rtampered_hash"
+		if merkle.VerifyMapProof("a", "1", tampered, root) {
+			t.Error("VerifyMapProof() = true, want false for tampered proof")
+		}
+	}
+}